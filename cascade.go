@@ -0,0 +1,30 @@
+package dagger
+
+import "github.com/autom8ter/dagger/primitive"
+
+// CascadeOptions controls what happens to an edge's endpoints when one of them is removed from the graph.
+// Pass a *CascadeOptions to (*Node).Connect to opt an edge into cascading deletes.
+type CascadeOptions struct {
+	// CascadeToTarget deletes the target node whenever the node this edge was created from is removed
+	CascadeToTarget bool
+	// CascadeFromTarget deletes the node this edge was created from whenever the target node is removed
+	CascadeFromTarget bool
+	// CascadeLastToTarget deletes the target node when the node this edge was created from is removed, but only if
+	// this was the target's last remaining edge of this relationship
+	CascadeLastToTarget bool
+	// CascadeLastFromTarget deletes the node this edge was created from when the target node is removed, but only if
+	// this was that node's last remaining edge of this relationship
+	CascadeLastFromTarget bool
+}
+
+func (c *CascadeOptions) toPrimitive() primitive.Cascade {
+	if c == nil {
+		return primitive.Cascade{}
+	}
+	return primitive.Cascade{
+		ToTarget:       c.CascadeToTarget,
+		FromTarget:     c.CascadeFromTarget,
+		LastToTarget:   c.CascadeLastToTarget,
+		LastFromTarget: c.CascadeLastFromTarget,
+	}
+}
@@ -0,0 +1,42 @@
+package dagger
+
+import (
+	"testing"
+
+	"github.com/autom8ter/dagger/primitive"
+)
+
+// TestCascadeLastToTarget verifies that a CascadeLastToTarget edge only removes its target once the node being
+// removed held the target's last remaining edge of that relationship.
+func TestCascadeLastToTarget(t *testing.T) {
+	owner1, err := NewNode(map[string]interface{}{primitive.TYPE_KEY: "cascade_owner", primitive.ID_KEY: "owner1"})
+	if err != nil {
+		t.Fatalf("NewNode(owner1): %v", err)
+	}
+	owner2, err := NewNode(map[string]interface{}{primitive.TYPE_KEY: "cascade_owner", primitive.ID_KEY: "owner2"})
+	if err != nil {
+		t.Fatalf("NewNode(owner2): %v", err)
+	}
+	target, err := NewNode(map[string]interface{}{primitive.TYPE_KEY: "cascade_target", primitive.ID_KEY: "target"})
+	if err != nil {
+		t.Fatalf("NewNode(target): %v", err)
+	}
+
+	opts := &CascadeOptions{CascadeLastToTarget: true}
+	if _, err := owner1.Connect(target, "owns", false, opts); err != nil {
+		t.Fatalf("Connect(owner1 -> target): %v", err)
+	}
+	if _, err := owner2.Connect(target, "owns", false, opts); err != nil {
+		t.Fatalf("Connect(owner2 -> target): %v", err)
+	}
+
+	owner1.Remove()
+	if _, ok := GetNode(target); !ok {
+		t.Fatalf("target should survive while owner2's edge still references it")
+	}
+
+	owner2.Remove()
+	if _, ok := GetNode(target); ok {
+		t.Fatalf("target should be removed once its last owning edge is gone")
+	}
+}
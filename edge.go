@@ -0,0 +1,50 @@
+package dagger
+
+import (
+	"errors"
+
+	"github.com/autom8ter/dagger/primitive"
+)
+
+// Edge is a connection between two nodes, created via (*Node).Connect
+type Edge struct {
+	primitive.Node
+	from *Node
+	to   *Node
+}
+
+// edgeFrom wraps a primitive.Edge fetched from g's store as an *Edge whose From/To nodes are bound back to g
+func edgeFrom(g *Graph, e *primitive.Edge) (*Edge, error) {
+	if e == nil || !e.HasID() {
+		return nil, errors.New("dagger: edge has no id")
+	}
+	return &Edge{Node: e.Node, from: &Node{graph: g, TypedID: e.From}, to: &Node{graph: g, TypedID: e.To}}, nil
+}
+
+// From returns the node the edge originates from
+func (e *Edge) From() *Node {
+	return e.from
+}
+
+// To returns the node the edge points to
+func (e *Edge) To() *Node {
+	return e.to
+}
+
+// Patch patches the edge's own attributes with the given data.
+// If the edge's relationship has a registered Schema(see RegisterType), the merged attributes must satisfy it.
+func (e *Edge) Patch(data map[string]interface{}) error {
+	merged := map[string]interface{}{}
+	e.Range(func(k string, v interface{}) bool {
+		merged[k] = v
+		return true
+	})
+	for k, v := range data {
+		merged[k] = v
+	}
+	if err := validateAttributes(e.Type(), merged); err != nil {
+		return err
+	}
+	e.SetAll(data)
+	return nil
+}
@@ -0,0 +1,133 @@
+package dagger
+
+import (
+	"sync"
+
+	"github.com/autom8ter/dagger/primitive"
+)
+
+// EventType identifies the kind of mutation an Event describes
+type EventType int
+
+const (
+	// NodeAdded fires when a node is created for the first time
+	NodeAdded EventType = iota
+	// NodeRemoved fires when a node is removed from the graph, including peers removed via CascadeOptions
+	NodeRemoved
+	// NodePatched fires when an existing node's attributes are overwritten
+	NodePatched
+	// EdgeAdded fires when an edge is created between two nodes
+	EdgeAdded
+	// EdgeRemoved fires when an edge is removed, including edges swept during a cascading node removal
+	EdgeRemoved
+)
+
+// Event describes a single mutation to the graph
+type Event struct {
+	Type EventType
+	// NodeType/NodeID identify the node a Node* event applies to
+	NodeType primitive.Type
+	NodeID   string
+	// Relationship identifies the edge type an Edge* event applies to
+	Relationship string
+	// From/To identify an edge's endpoints; only set for Edge* events
+	From primitive.TypedID
+	To   primitive.TypedID
+	// Attributes is the node's(or edge's own) attributes at the time of the event
+	Attributes map[string]interface{}
+}
+
+// EventFilter selects which events a subscriber receives. Zero-value fields match anything.
+type EventFilter struct {
+	// Type matches a node's type(Node* events) or an edge's relationship(Edge* events)
+	Type primitive.Type
+	// Relationship matches an edge's relationship. Ignored for Node* events.
+	Relationship string
+	// Predicate, if set, is run against the event's attributes; events are dropped unless it returns true
+	Predicate func(attrs map[string]interface{}) bool
+}
+
+func (f EventFilter) matches(e Event) bool {
+	switch e.Type {
+	case NodeAdded, NodeRemoved, NodePatched:
+		if f.Type != "" && f.Type != e.NodeType {
+			return false
+		}
+	case EdgeAdded, EdgeRemoved:
+		if f.Type != "" && f.Type != primitive.Type(e.Relationship) {
+			return false
+		}
+		if f.Relationship != "" && f.Relationship != e.Relationship {
+			return false
+		}
+	}
+	if f.Predicate != nil && !f.Predicate(e.Attributes) {
+		return false
+	}
+	return true
+}
+
+// eventBufferSize is the capacity of each subscriber's ring buffer
+const eventBufferSize = 64
+
+// subscriber fans events matching filter into a bounded, drop-oldest channel
+type subscriber struct {
+	filter EventFilter
+	ch     chan Event
+	mu     sync.Mutex
+}
+
+// publish delivers e to the subscriber, dropping the oldest buffered event if the channel is full so that a slow
+// consumer can never stall the writer that produced e.
+func (s *subscriber) publish(e Event) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	select {
+	case s.ch <- e:
+		return
+	default:
+	}
+	select {
+	case <-s.ch:
+	default:
+	}
+	select {
+	case s.ch <- e:
+	default:
+	}
+}
+
+var (
+	subscribersMu sync.RWMutex
+	subscribers   = map[int]*subscriber{}
+	nextSubID     int
+)
+
+// Subscribe registers filter and returns a channel of matching events along with a cancel func that must be called
+// to stop receiving events and release the subscription.
+func Subscribe(filter EventFilter) (<-chan Event, func()) {
+	subscribersMu.Lock()
+	id := nextSubID
+	nextSubID++
+	sub := &subscriber{filter: filter, ch: make(chan Event, eventBufferSize)}
+	subscribers[id] = sub
+	subscribersMu.Unlock()
+
+	cancel := func() {
+		subscribersMu.Lock()
+		delete(subscribers, id)
+		subscribersMu.Unlock()
+	}
+	return sub.ch, cancel
+}
+
+// publish fans e out to every subscriber whose filter matches it
+func publish(e Event) {
+	subscribersMu.RLock()
+	defer subscribersMu.RUnlock()
+	for _, sub := range subscribers {
+		if sub.filter.matches(e) {
+			sub.publish(e)
+		}
+	}
+}
@@ -0,0 +1,93 @@
+package dagger
+
+import (
+	"testing"
+	"time"
+
+	"github.com/autom8ter/dagger/primitive"
+)
+
+func recvEvent(t *testing.T, ch <-chan Event) Event {
+	t.Helper()
+	select {
+	case e := <-ch:
+		return e
+	case <-time.After(time.Second):
+		t.Fatal("timed out waiting for event")
+		return Event{}
+	}
+}
+
+func TestSubscribeReceivesNodeLifecycleEvents(t *testing.T) {
+	ch, cancel := Subscribe(EventFilter{Type: "pubsub_node"})
+	defer cancel()
+
+	node, err := NewNode(map[string]interface{}{primitive.TYPE_KEY: "pubsub_node", primitive.ID_KEY: "n1"})
+	if err != nil {
+		t.Fatalf("NewNode: %v", err)
+	}
+	if e := recvEvent(t, ch); e.Type != NodeAdded {
+		t.Fatalf("expected NodeAdded, got %v", e.Type)
+	}
+
+	if err := node.Patch(map[string]interface{}{"color": "red"}); err != nil {
+		t.Fatalf("Patch: %v", err)
+	}
+	if e := recvEvent(t, ch); e.Type != NodePatched {
+		t.Fatalf("expected NodePatched, got %v", e.Type)
+	}
+
+	node.Remove()
+	if e := recvEvent(t, ch); e.Type != NodeRemoved {
+		t.Fatalf("expected NodeRemoved, got %v", e.Type)
+	}
+}
+
+func TestSubscribeFilterIgnoresOtherTypes(t *testing.T) {
+	ch, cancel := Subscribe(EventFilter{Type: "pubsub_other_wanted"})
+	defer cancel()
+
+	if _, err := NewNode(map[string]interface{}{primitive.TYPE_KEY: "pubsub_other_unwanted", primitive.ID_KEY: "n2"}); err != nil {
+		t.Fatalf("NewNode: %v", err)
+	}
+	select {
+	case e := <-ch:
+		t.Fatalf("expected no event to match the filter, got %v", e)
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestSubscribeCancelStopsDelivery(t *testing.T) {
+	ch, cancel := Subscribe(EventFilter{Type: "pubsub_cancelled"})
+	cancel()
+
+	if _, err := NewNode(map[string]interface{}{primitive.TYPE_KEY: "pubsub_cancelled", primitive.ID_KEY: "n3"}); err != nil {
+		t.Fatalf("NewNode: %v", err)
+	}
+	select {
+	case e, ok := <-ch:
+		if ok {
+			t.Fatalf("expected no event after cancel, got %v", e)
+		}
+	case <-time.After(50 * time.Millisecond):
+	}
+}
+
+func TestSubscribeRingBufferDropsOldest(t *testing.T) {
+	ch, cancel := Subscribe(EventFilter{Type: "pubsub_ring"})
+	defer cancel()
+
+	const total = eventBufferSize + 10
+	for i := 0; i < total; i++ {
+		if _, err := NewNode(map[string]interface{}{primitive.TYPE_KEY: "pubsub_ring", primitive.ID_KEY: string(rune('a' + i))}); err != nil {
+			t.Fatalf("NewNode %d: %v", i, err)
+		}
+	}
+	if len(ch) != eventBufferSize {
+		t.Fatalf("expected the ring buffer to be full at %d events, got %d", eventBufferSize, len(ch))
+	}
+	first := recvEvent(t, ch)
+	if first.NodeID == "a" {
+		t.Fatalf("expected the oldest events to have been dropped, but the first buffered event was the very first node added")
+	}
+}
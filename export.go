@@ -0,0 +1,142 @@
+package dagger
+
+import (
+	"bufio"
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"github.com/autom8ter/dagger/primitive"
+)
+
+// Export writes a snapshot of the default graph to w in the given Format
+func Export(w io.Writer, format Format) error {
+	return globalGraph.Export(w, format)
+}
+
+// Export writes a snapshot of the graph to w in the given Format, streaming node-by-node rather than buffering
+// the whole graph in memory.
+func (g *Graph) Export(w io.Writer, format Format) error {
+	switch format {
+	case FormatJSONL:
+		return g.exportJSONL(w)
+	case FormatGraphML:
+		return g.exportGraphML(w)
+	default:
+		return fmt.Errorf("dagger: unknown export format %d", format)
+	}
+}
+
+// exportJSONL writes every node before any edge, so that a streaming import never sees an edge before the nodes
+// it references - EdgesFrom can otherwise yield a peer the importer hasn't created yet.
+func (g *Graph) exportJSONL(w io.Writer) error {
+	enc := json.NewEncoder(w)
+	var outerErr error
+	g.Range("", func(node primitive.Node) bool {
+		outerErr = enc.Encode(record{Kind: "node", Type: string(node.Type()), ID: node.ID(), Attributes: node})
+		return outerErr == nil
+	})
+	if outerErr != nil {
+		return outerErr
+	}
+	g.Range("", func(node primitive.Node) bool {
+		g.EdgesFrom("", node, func(e *primitive.Edge) bool {
+			outerErr = enc.Encode(record{
+				Kind:       "edge",
+				Type:       string(e.Type()),
+				ID:         e.ID(),
+				From:       key(e.From),
+				To:         key(e.To),
+				Attributes: e.Node,
+			})
+			return outerErr == nil
+		})
+		return outerErr == nil
+	})
+	return outerErr
+}
+
+// GraphML element shapes, see http://graphml.graphdrawing.org/
+type graphmlData struct {
+	Key   string `xml:"key,attr"`
+	Value string `xml:",chardata"`
+}
+type graphmlNode struct {
+	XMLName xml.Name      `xml:"node"`
+	ID      string        `xml:"id,attr"`
+	Data    []graphmlData `xml:"data"`
+}
+type graphmlEdge struct {
+	XMLName xml.Name      `xml:"edge"`
+	ID      string        `xml:"id,attr"`
+	Source  string        `xml:"source,attr"`
+	Target  string        `xml:"target,attr"`
+	Data    []graphmlData `xml:"data"`
+}
+
+func attrData(attrs map[string]interface{}) []graphmlData {
+	var data []graphmlData
+	for k, v := range attrs {
+		if k == primitive.TYPE_KEY || k == primitive.ID_KEY {
+			continue
+		}
+		bits, err := json.Marshal(v)
+		if err != nil {
+			continue
+		}
+		data = append(data, graphmlData{Key: k, Value: string(bits)})
+	}
+	return data
+}
+
+// exportGraphML writes every node before any edge, so that a streaming import never sees an edge before the nodes
+// it references - EdgesFrom can otherwise yield a peer the importer hasn't created yet.
+func (g *Graph) exportGraphML(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	enc := xml.NewEncoder(bw)
+	if _, err := bw.WriteString(xml.Header); err != nil {
+		return err
+	}
+	if err := enc.EncodeToken(xml.StartElement{Name: xml.Name{Local: "graphml"}}); err != nil {
+		return err
+	}
+	graphStart := xml.StartElement{Name: xml.Name{Local: "graph"}, Attr: []xml.Attr{{Name: xml.Name{Local: "edgedefault"}, Value: "directed"}}}
+	if err := enc.EncodeToken(graphStart); err != nil {
+		return err
+	}
+
+	var outerErr error
+	g.Range("", func(node primitive.Node) bool {
+		outerErr = enc.Encode(graphmlNode{ID: key(node), Data: attrData(node)})
+		return outerErr == nil
+	})
+	if outerErr != nil {
+		return outerErr
+	}
+	g.Range("", func(node primitive.Node) bool {
+		g.EdgesFrom("", node, func(e *primitive.Edge) bool {
+			outerErr = enc.Encode(graphmlEdge{
+				ID:     key(e),
+				Source: key(e.From),
+				Target: key(e.To),
+				Data:   attrData(e.Node),
+			})
+			return outerErr == nil
+		})
+		return outerErr == nil
+	})
+	if outerErr != nil {
+		return outerErr
+	}
+	if err := enc.EncodeToken(graphStart.End()); err != nil {
+		return err
+	}
+	if err := enc.EncodeToken(xml.EndElement{Name: xml.Name{Local: "graphml"}}); err != nil {
+		return err
+	}
+	if err := enc.Flush(); err != nil {
+		return err
+	}
+	return bw.Flush()
+}
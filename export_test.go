@@ -0,0 +1,93 @@
+package dagger
+
+import (
+	"bytes"
+	"testing"
+
+	"github.com/autom8ter/dagger/primitive"
+)
+
+// buildExportFixture creates a small graph with enough nodes that at least one edge is very likely to be ordered
+// before its target node in the underlying memStore's (randomly ordered) map, exercising the two-pass export.
+func buildExportFixture(t *testing.T) *Graph {
+	t.Helper()
+	g := NewGraph(newMemStore())
+	var nodes []*Node
+	for i := 0; i < 20; i++ {
+		n, err := g.NewNode(map[string]interface{}{primitive.TYPE_KEY: "export_node", primitive.ID_KEY: string(rune('a' + i))})
+		if err != nil {
+			t.Fatalf("NewNode: %v", err)
+		}
+		nodes = append(nodes, n)
+	}
+	for i := 1; i < len(nodes); i++ {
+		if _, err := nodes[i-1].Connect(nodes[i], "next", false, nil); err != nil {
+			t.Fatalf("Connect: %v", err)
+		}
+	}
+	return g
+}
+
+func assertRoundTrip(t *testing.T, g *Graph, format Format) {
+	t.Helper()
+	var buf bytes.Buffer
+	if err := g.Export(&buf, format); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+
+	into := NewGraph(newMemStore())
+	if err := into.Import(&buf, format); err != nil {
+		t.Fatalf("Import: %v", err)
+	}
+
+	var wantNodes, gotNodes int
+	g.Range("", func(primitive.Node) bool { wantNodes++; return true })
+	into.Range("", func(primitive.Node) bool { gotNodes++; return true })
+	if wantNodes != gotNodes {
+		t.Fatalf("expected %d nodes after round-trip, got %d", wantNodes, gotNodes)
+	}
+
+	var wantEdges, gotEdges int
+	g.Range("", func(node primitive.Node) bool {
+		g.EdgesFrom("", node, func(*primitive.Edge) bool { wantEdges++; return true })
+		return true
+	})
+	into.Range("", func(node primitive.Node) bool {
+		into.EdgesFrom("", node, func(*primitive.Edge) bool { gotEdges++; return true })
+		return true
+	})
+	if wantEdges != gotEdges {
+		t.Fatalf("expected %d edges after round-trip, got %d", wantEdges, gotEdges)
+	}
+}
+
+func TestExportImportJSONLRoundTrip(t *testing.T) {
+	assertRoundTrip(t, buildExportFixture(t), FormatJSONL)
+}
+
+func TestExportImportGraphMLRoundTrip(t *testing.T) {
+	assertRoundTrip(t, buildExportFixture(t), FormatGraphML)
+}
+
+func TestImportIsIdempotent(t *testing.T) {
+	g := buildExportFixture(t)
+	var buf bytes.Buffer
+	if err := g.Export(&buf, FormatJSONL); err != nil {
+		t.Fatalf("Export: %v", err)
+	}
+	bits := buf.Bytes()
+
+	into := NewGraph(newMemStore())
+	if err := into.Import(bytes.NewReader(bits), FormatJSONL); err != nil {
+		t.Fatalf("first Import: %v", err)
+	}
+	if err := into.Import(bytes.NewReader(bits), FormatJSONL); err != nil {
+		t.Fatalf("second Import: %v", err)
+	}
+
+	var nodeCount int
+	into.Range("", func(primitive.Node) bool { nodeCount++; return true })
+	if nodeCount != 20 {
+		t.Fatalf("expected re-importing the same snapshot to leave 20 nodes, got %d", nodeCount)
+	}
+}
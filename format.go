@@ -0,0 +1,21 @@
+package dagger
+
+// Format selects the on-disk encoding used by Export/Import
+type Format int
+
+const (
+	// FormatJSONL encodes one node or edge per line as JSON, streaming-friendly for large graphs
+	FormatJSONL Format = iota
+	// FormatGraphML encodes the graph as GraphML, an XML dialect consumable by Gephi/Cytoscape
+	FormatGraphML
+)
+
+// record is the JSONL wire format for a single node or edge
+type record struct {
+	Kind       string                 `json:"kind"`
+	Type       string                 `json:"type"`
+	ID         string                 `json:"id"`
+	From       string                 `json:"from,omitempty"`
+	To         string                 `json:"to,omitempty"`
+	Attributes map[string]interface{} `json:"attributes"`
+}
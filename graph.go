@@ -0,0 +1,212 @@
+package dagger
+
+import (
+	"github.com/autom8ter/dagger/primitive"
+)
+
+// Graph is a collection of nodes and edges backed by a pluggable Store.
+// The package-level functions (NewNode, Connect, GetNode, etc) operate against the default, in-memory globalGraph.
+// Use Open to work against a persistent, on-disk Graph instead: *Node's returned by g.NewNode/g.Node carry a
+// reference back to g, so their Connect/Patch/Remove/EdgesFrom/etc methods write through to g's store.
+type Graph struct {
+	store Store
+}
+
+// NewGraph wraps the given Store in a Graph
+func NewGraph(store Store) *Graph {
+	return &Graph{store: store}
+}
+
+// globalGraph is the default, in-memory graph that the package-level functions (NewNode, Connect, ...) operate on
+var globalGraph = NewGraph(newMemStore())
+
+// OpenOption configures a Graph opened via Open
+type OpenOption func(*openOptions)
+
+type openOptions struct {
+	store Store
+}
+
+// WithStore overrides the default boltdb-backed Store that Open uses, eg: for a custom or test Store
+func WithStore(store Store) OpenOption {
+	return func(o *openOptions) {
+		o.store = store
+	}
+}
+
+// Open opens (creating if necessary) a Graph persisted to a boltdb file at path
+func Open(path string, opts ...OpenOption) (*Graph, error) {
+	options := &openOptions{}
+	for _, opt := range opts {
+		opt(options)
+	}
+	if options.store != nil {
+		return NewGraph(options.store), nil
+	}
+	store, err := newBoltStore(path)
+	if err != nil {
+		return nil, err
+	}
+	return NewGraph(store), nil
+}
+
+// GetNode fetches a node from the default, in-memory graph by id
+func GetNode(id primitive.TypedID) (*Node, bool) {
+	return globalGraph.Node(id)
+}
+
+// Node fetches a node from the graph by id, returning it bound to g so that its Connect/Patch/Remove/EdgesFrom/etc
+// methods write through to g's store rather than the default, in-memory globalGraph.
+func (g *Graph) Node(id primitive.TypedID) (*Node, bool) {
+	node, ok := g.GetNode(id)
+	if !ok {
+		return nil, false
+	}
+	return &Node{graph: g, TypedID: node}, true
+}
+
+// HasNode returns true if the node already exists in the graph
+func (g *Graph) HasNode(id primitive.TypedID) bool {
+	_, ok, _ := g.store.GetNode(id)
+	return ok
+}
+
+// AddNode creates or overwrites a node in the graph, publishing a NodeAdded or NodePatched event to subscribers
+func (g *Graph) AddNode(node primitive.Node) error {
+	_, existed, _ := g.store.GetNode(node)
+	if err := g.store.PutNode(node); err != nil {
+		return err
+	}
+	eventType := NodeAdded
+	if existed {
+		eventType = NodePatched
+	}
+	publish(Event{Type: eventType, NodeType: node.Type(), NodeID: node.ID(), Attributes: node})
+	return nil
+}
+
+// GetNode fetches a node from the graph by id
+func (g *Graph) GetNode(id primitive.TypedID) (primitive.Node, bool) {
+	node, ok, err := g.store.GetNode(id)
+	if err != nil {
+		return nil, false
+	}
+	return node, ok
+}
+
+// DelNode removes a node from the graph. Its edges are swept from both endpoints so none are left dangling, and
+// any peer connected via a cascading edge(see CascadeOptions) is recursively removed as well.
+func (g *Graph) DelNode(id primitive.TypedID) error {
+	return g.delNode(id, map[string]bool{})
+}
+
+// delNode performs the actual cascade sweep. removing guards against cycles between mutually cascading edges.
+func (g *Graph) delNode(id primitive.TypedID, removing map[string]bool) error {
+	k := key(id)
+	if removing[k] {
+		return nil
+	}
+	removing[k] = true
+
+	var outbound, inbound []*primitive.Edge
+	g.store.EdgesFrom("", id, func(e *primitive.Edge) bool {
+		outbound = append(outbound, e)
+		return true
+	})
+	g.store.EdgesTo("", id, func(e *primitive.Edge) bool {
+		inbound = append(inbound, e)
+		return true
+	})
+
+	for _, e := range outbound {
+		if err := g.store.DeleteEdge(e); err != nil {
+			return err
+		}
+		publish(Event{Type: EdgeRemoved, Relationship: string(e.Type()), From: e.From, To: e.To, Attributes: e.Node})
+		switch {
+		case e.Cascade.ToTarget:
+			if err := g.delNode(e.To, removing); err != nil {
+				return err
+			}
+		case e.Cascade.LastToTarget && !g.hasEdge(g.store.EdgesTo, e.Type(), e.To):
+			if err := g.delNode(e.To, removing); err != nil {
+				return err
+			}
+		}
+	}
+	for _, e := range inbound {
+		if err := g.store.DeleteEdge(e); err != nil {
+			return err
+		}
+		publish(Event{Type: EdgeRemoved, Relationship: string(e.Type()), From: e.From, To: e.To, Attributes: e.Node})
+		switch {
+		case e.Cascade.FromTarget:
+			if err := g.delNode(e.From, removing); err != nil {
+				return err
+			}
+		case e.Cascade.LastFromTarget && !g.hasEdge(g.store.EdgesFrom, e.Type(), e.From):
+			if err := g.delNode(e.From, removing); err != nil {
+				return err
+			}
+		}
+	}
+	node, _ := g.GetNode(id)
+	if err := g.store.DeleteNode(id); err != nil {
+		return err
+	}
+	publish(Event{Type: NodeRemoved, NodeType: id.Type(), NodeID: id.ID(), Attributes: node})
+	return nil
+}
+
+// hasEdge reports whether the given ranger(EdgesFrom/EdgesTo) yields at least one edge of edgeType for id
+func (g *Graph) hasEdge(ranger func(primitive.Type, primitive.TypedID, func(*primitive.Edge) bool) error, edgeType primitive.Type, id primitive.TypedID) bool {
+	found := false
+	ranger(edgeType, id, func(*primitive.Edge) bool {
+		found = true
+		return false
+	})
+	return found
+}
+
+// AddEdge connects edge.From to edge.To in the graph, publishing an EdgeAdded event to subscribers
+func (g *Graph) AddEdge(edge *primitive.Edge) error {
+	if !edge.HasID() {
+		edge.Set(primitive.ID_KEY, primitive.NewNode(nil).ID())
+	}
+	if err := g.store.PutEdge(edge); err != nil {
+		return err
+	}
+	publish(Event{Type: EdgeAdded, Relationship: string(edge.Type()), From: edge.From, To: edge.To, Attributes: edge.Node})
+	return nil
+}
+
+// DelEdge removes an edge from the graph, publishing an EdgeRemoved event to subscribers
+func (g *Graph) DelEdge(edge *primitive.Edge) error {
+	if err := g.store.DeleteEdge(edge); err != nil {
+		return err
+	}
+	publish(Event{Type: EdgeRemoved, Relationship: string(edge.Type()), From: edge.From, To: edge.To, Attributes: edge.Node})
+	return nil
+}
+
+// EdgesFrom streams edges of the given type that originate at "from", stopping early if fn returns false.
+// An empty edgeType streams every outbound edge regardless of relationship.
+func (g *Graph) EdgesFrom(edgeType primitive.Type, from primitive.TypedID, fn func(e *primitive.Edge) bool) {
+	g.store.EdgesFrom(edgeType, from, fn)
+}
+
+// EdgesTo streams edges of the given type that terminate at "to", stopping early if fn returns false.
+func (g *Graph) EdgesTo(edgeType primitive.Type, to primitive.TypedID, fn func(e *primitive.Edge) bool) {
+	g.store.EdgesTo(edgeType, to, fn)
+}
+
+// Range streams every node of the given type in the graph, stopping early if fn returns false.
+// An empty nodeType ranges over every node in the graph.
+func (g *Graph) Range(nodeType primitive.Type, fn func(node primitive.Node) bool) {
+	g.store.RangeNodes(nodeType, fn)
+}
+
+// Close releases any resources(eg: open file handles) held by the graph's store
+func (g *Graph) Close() error {
+	return g.store.Close()
+}
@@ -0,0 +1,131 @@
+package dagger
+
+import (
+	"encoding/json"
+	"encoding/xml"
+	"fmt"
+	"io"
+
+	"github.com/autom8ter/dagger/primitive"
+)
+
+// Import reads a snapshot previously written by Export into the default graph. Import is idempotent: importing the
+// same snapshot twice leaves the graph unchanged the second time, since nodes/edges are addressed by their original
+// type/id rather than appended.
+func Import(r io.Reader, format Format) error {
+	return globalGraph.Import(r, format)
+}
+
+// Import reads a snapshot previously written by Export into the graph, streaming rather than buffering the whole
+// input in memory.
+func (g *Graph) Import(r io.Reader, format Format) error {
+	switch format {
+	case FormatJSONL:
+		return g.importJSONL(r)
+	case FormatGraphML:
+		return g.importGraphML(r)
+	default:
+		return fmt.Errorf("dagger: unknown import format %d", format)
+	}
+}
+
+func (g *Graph) importJSONL(r io.Reader) error {
+	dec := json.NewDecoder(r)
+	for {
+		var rec record
+		if err := dec.Decode(&rec); err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		node := primitive.Node{}
+		node.SetAll(rec.Attributes)
+		node.Set(primitive.TYPE_KEY, rec.Type)
+		node.Set(primitive.ID_KEY, rec.ID)
+
+		switch rec.Kind {
+		case "node":
+			if err := g.AddNode(node); err != nil {
+				return err
+			}
+		case "edge":
+			from, ok := g.GetNode(parseKey(rec.From))
+			if !ok {
+				return fmt.Errorf("dagger: import: edge %s %s references missing from-node %s", rec.Type, rec.ID, rec.From)
+			}
+			to, ok := g.GetNode(parseKey(rec.To))
+			if !ok {
+				return fmt.Errorf("dagger: import: edge %s %s references missing to-node %s", rec.Type, rec.ID, rec.To)
+			}
+			if err := g.AddEdge(&primitive.Edge{Node: node, From: from, To: to}); err != nil {
+				return err
+			}
+		default:
+			return fmt.Errorf("dagger: import: unknown record kind %q", rec.Kind)
+		}
+	}
+}
+
+func attrsFromData(data []graphmlData) map[string]interface{} {
+	attrs := map[string]interface{}{}
+	for _, d := range data {
+		var v interface{}
+		if err := json.Unmarshal([]byte(d.Value), &v); err != nil {
+			v = d.Value
+		}
+		attrs[d.Key] = v
+	}
+	return attrs
+}
+
+func (g *Graph) importGraphML(r io.Reader) error {
+	dec := xml.NewDecoder(r)
+	for {
+		tok, err := dec.Token()
+		if err == io.EOF {
+			return nil
+		} else if err != nil {
+			return err
+		}
+		start, ok := tok.(xml.StartElement)
+		if !ok {
+			continue
+		}
+		switch start.Name.Local {
+		case "node":
+			var n graphmlNode
+			if err := dec.DecodeElement(&n, &start); err != nil {
+				return err
+			}
+			id := parseKey(n.ID)
+			node := primitive.Node{}
+			node.SetAll(attrsFromData(n.Data))
+			node.Set(primitive.TYPE_KEY, string(id.Type()))
+			node.Set(primitive.ID_KEY, id.ID())
+			if err := g.AddNode(node); err != nil {
+				return err
+			}
+		case "edge":
+			var e graphmlEdge
+			if err := dec.DecodeElement(&e, &start); err != nil {
+				return err
+			}
+			edgeID := parseKey(e.ID)
+			from, ok := g.GetNode(parseKey(e.Source))
+			if !ok {
+				return fmt.Errorf("dagger: import: edge %s references missing source node %s", e.ID, e.Source)
+			}
+			to, ok := g.GetNode(parseKey(e.Target))
+			if !ok {
+				return fmt.Errorf("dagger: import: edge %s references missing target node %s", e.ID, e.Target)
+			}
+			edgeNode := primitive.Node{}
+			edgeNode.SetAll(attrsFromData(e.Data))
+			edgeNode.Set(primitive.TYPE_KEY, string(edgeID.Type()))
+			edgeNode.Set(primitive.ID_KEY, edgeID.ID())
+			if err := g.AddEdge(&primitive.Edge{Node: edgeNode, From: from, To: to}); err != nil {
+				return err
+			}
+		}
+	}
+}
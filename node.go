@@ -1,32 +1,54 @@
 package dagger
 
 import (
-	"errors"
+	"encoding/json"
 	"fmt"
+
 	"github.com/autom8ter/dagger/primitive"
 )
 
-// NewNode creates a new node in the global, in-memory graph.
+// NewNode creates a new node in the default, in-memory graph.
 // If an id is not provided, a random uuid will be assigned.
-func NewNode(attributes map[string]interface{}) *Node {
+// If the node's type has a registered Schema(see RegisterType), the attributes must satisfy it.
+func NewNode(attributes map[string]interface{}) (*Node, error) {
+	return globalGraph.NewNode(attributes)
+}
+
+// NewNode creates a new node in the graph, writing through to g's store.
+// If an id is not provided, a random uuid will be assigned.
+// If the node's type has a registered Schema(see RegisterType), the attributes must satisfy it.
+func (g *Graph) NewNode(attributes map[string]interface{}) (*Node, error) {
 	data := primitive.NewNode(attributes)
 	data.SetAll(attributes)
-	return nodeFrom(data)
+	if err := validateAttributes(data.Type(), data); err != nil {
+		return nil, err
+	}
+	return nodeFrom(g, data), nil
 }
 
-func nodeFrom(node primitive.Node) *Node {
-	if !globalGraph.HasNode(node) || !node.HasID() {
-		globalGraph.AddNode(node)
-		return &Node{node}
+func nodeFrom(g *Graph, node primitive.Node) *Node {
+	if !g.HasNode(node) || !node.HasID() {
+		g.AddNode(node)
 	}
-	return &Node{TypedID: node}
+	return &Node{graph: g, TypedID: node}
 }
 
-// Node is the most basic element in the graph. Node's may be connected with one another via edges to represent relationships
+// Node is the most basic element in the graph. Node's may be connected with one another via edges to represent relationships.
+// A Node created via a *Graph's NewNode/Node methods is bound to that graph, so its methods write through to/read from
+// that graph's store; a zero-value graph falls back to the default, in-memory globalGraph.
 type Node struct {
+	graph *Graph
 	primitive.TypedID
 }
 
+// g returns the graph this node is bound to, falling back to the default, in-memory globalGraph
+func (n *Node) g() *Graph {
+	if n.graph != nil {
+		return n.graph
+	}
+	return globalGraph
+}
+
 func (n *Node) attributes() map[string]interface{} {
 	return map[string]interface{}{
 		primitive.TYPE_KEY: n.Type(),
@@ -35,18 +57,18 @@ func (n *Node) attributes() map[string]interface{} {
 }
 
 func (n *Node) load() primitive.Node {
-	node, ok := globalGraph.GetNode(n)
+	node, ok := n.g().GetNode(n)
 	if !ok {
-		globalGraph.AddNode(primitive.NewNode(n.attributes()))
-		node, ok = globalGraph.GetNode(n)
+		n.g().AddNode(primitive.NewNode(n.attributes()))
+		node, ok = n.g().GetNode(n)
 	}
 	return node
 }
 
 // EdgesFrom returns connections/edges that stem from the node/vertex
 func (n *Node) EdgesFrom(edgeType primitive.Type, fn func(edge *Edge) bool) {
-	globalGraph.EdgesFrom(edgeType, n, func(e *primitive.Edge) bool {
-		this, err := edgeFrom(e)
+	n.g().EdgesFrom(edgeType, n, func(e *primitive.Edge) bool {
+		this, err := edgeFrom(n.g(), e)
 		if err != nil {
 			return true
 		}
@@ -56,8 +78,8 @@ func (n *Node) EdgesFrom(edgeType primitive.Type, fn func(edge *Edge) bool) {
 
 // EdgesTo returns connections/edges that point toward the node/vertex
 func (n *Node) EdgesTo(edgeType primitive.Type, fn func(e *Edge) bool) {
-	globalGraph.EdgesTo(edgeType, n, func(e *primitive.Edge) bool {
-		this, err := edgeFrom(e)
+	n.g().EdgesTo(edgeType, n, func(e *primitive.Edge) bool {
+		this, err := edgeFrom(n.g(), e)
 		if err != nil {
 			return true
 		}
@@ -67,54 +89,75 @@ func (n *Node) EdgesTo(edgeType primitive.Type, fn func(e *Edge) bool) {
 
 // Remove permenently removes the node from the graph
 func (n *Node) Remove() {
-	globalGraph.DelNode(n)
+	n.g().DelNode(n)
 }
 
-// Connect creates a connection/edge between the two nodes with the given relationship type
-// if mutual = true, the connection is doubly linked - (facebook is mutual, instagram is not)
-func (n *Node) Connect(nodeID primitive.TypedID, relationship string, mutual bool) (*Edge, error) {
+// Connect creates a connection/edge between the two nodes with the given relationship type.
+// if mutual = true, the connection is doubly linked - (facebook is mutual, instagram is not).
+// cascade may be nil; when set it controls whether either endpoint is removed along with the other on delete.
+func (n *Node) Connect(nodeID primitive.TypedID, relationship string, mutual bool, cascade *CascadeOptions) (*Edge, error) {
 	en := primitive.NewNode(map[string]interface{}{
 		primitive.TYPE_KEY: relationship,
 	})
-	node, ok := GetNode(nodeID)
+	node, ok := n.g().Node(nodeID)
 	if !ok {
 		return nil, fmt.Errorf("node: %s %s does not exist", nodeID.Type(), nodeID.ID())
 	}
+	if err := checkRelationship(n, node, relationship); err != nil {
+		return nil, err
+	}
 	if !mutual {
-		if err := globalGraph.AddEdge(&primitive.Edge{
-			Node: en,
-			From: n.load(),
-			To:   node.load(),
+		if err := n.g().AddEdge(&primitive.Edge{
+			Node:    en,
+			From:    n.load(),
+			To:      node.load(),
+			Cascade: cascade.toPrimitive(),
 		}); err != nil {
 			return nil, err
 		}
 	} else {
-		if err := globalGraph.AddEdge(&primitive.Edge{
-			Node: en,
-			From: n.load(),
-			To:   node.load(),
+		if err := n.g().AddEdge(&primitive.Edge{
+			Node:    en,
+			From:    n.load(),
+			To:      node.load(),
+			Cascade: cascade.toPrimitive(),
 		}); err != nil {
 			return nil, err
 		}
-		if err := globalGraph.AddEdge(&primitive.Edge{
-			Node: en,
-			From: node.load(),
-			To:   n.load(),
+		// the reverse edge gets its own node/id - sharing en's id would collide with the forward edge in the
+		// store's edges bucket, which is keyed by relationship|id
+		ren := primitive.NewNode(map[string]interface{}{
+			primitive.TYPE_KEY: relationship,
+		})
+		if err := n.g().AddEdge(&primitive.Edge{
+			Node:    ren,
+			From:    node.load(),
+			To:      n.load(),
+			Cascade: cascade.toPrimitive(),
 		}); err != nil {
 			return nil, err
 		}
 	}
-	if !ok {
-		return nil, errors.New("failed to created edge")
-	}
-	return &Edge{en}, nil
+	return &Edge{Node: en, from: n, to: node}, nil
 }
 
-// Patch patches the node attributes with the given data
-func (n *Node) Patch(data map[string]interface{}) {
+// Patch patches the node attributes with the given data.
+// If the node's type has a registered Schema, the merged attributes must satisfy it.
+func (n *Node) Patch(data map[string]interface{}) error {
 	node := n.load()
+	merged := map[string]interface{}{}
+	node.Range(func(k string, v interface{}) bool {
+		merged[k] = v
+		return true
+	})
+	for k, v := range data {
+		merged[k] = v
+	}
+	if err := validateAttributes(node.Type(), merged); err != nil {
+		return err
+	}
 	node.SetAll(data)
-	globalGraph.AddNode(node)
+	return n.g().AddNode(node)
 }
 
 // Range iterates over the nodes attributes until the iterator returns false
@@ -158,9 +201,25 @@ func (n *Node) JSON() ([]byte, error) {
 	return n.load().JSON()
 }
 
-// FromJSON encodes the node with the given JSON bytes
+// FromJSON merges the given JSON bytes into the node's attributes.
+// If the node's type has a registered Schema, the merged attributes must satisfy it.
 func (n *Node) FromJSON(bits []byte) error {
 	node := n.load()
+	var data map[string]interface{}
+	if err := json.Unmarshal(bits, &data); err != nil {
+		return err
+	}
+	merged := map[string]interface{}{}
+	node.Range(func(k string, v interface{}) bool {
+		merged[k] = v
+		return true
+	})
+	for k, v := range data {
+		merged[k] = v
+	}
+	if err := validateAttributes(node.Type(), merged); err != nil {
+		return err
+	}
 	return node.FromJSON(bits)
 }
 
@@ -0,0 +1,23 @@
+package primitive
+
+// Edge is a directed connection between two nodes, carrying its own attributes(relationship type, weight, etc)
+type Edge struct {
+	Node
+	From    Node
+	To      Node
+	Cascade Cascade
+}
+
+// Cascade declares what should happen to an edge's endpoints when the other endpoint is removed from the graph
+type Cascade struct {
+	// ToTarget deletes the To node whenever the From node is removed
+	ToTarget bool
+	// FromTarget deletes the From node whenever the To node is removed
+	FromTarget bool
+	// LastToTarget deletes the To node when the From node is removed, but only if this was the To node's last
+	// remaining edge of this relationship
+	LastToTarget bool
+	// LastFromTarget deletes the From node when the To node is removed, but only if this was the From node's last
+	// remaining edge of this relationship
+	LastFromTarget bool
+}
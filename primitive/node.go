@@ -0,0 +1,108 @@
+package primitive
+
+import (
+	"encoding/json"
+
+	"github.com/google/uuid"
+)
+
+// Node is a map of attributes that represents a node (or an edge's own attributes) in the graph.
+// It implements TypedID via its reserved TYPE_KEY/ID_KEY entries.
+type Node map[string]interface{}
+
+// NewNode creates a Node from the given attributes, assigning a random uuid as its id if one isn't present
+func NewNode(attributes map[string]interface{}) Node {
+	n := Node{}
+	n.SetAll(attributes)
+	if n.GetString(TYPE_KEY) == "" {
+		n.Set(TYPE_KEY, "default")
+	}
+	if n.GetString(ID_KEY) == "" {
+		n.Set(ID_KEY, uuid.New().String())
+	}
+	return n
+}
+
+// Type returns the nodes type
+func (n Node) Type() Type {
+	return Type(n.GetString(TYPE_KEY))
+}
+
+// ID returns the nodes unique id
+func (n Node) ID() string {
+	return n.GetString(ID_KEY)
+}
+
+// HasID returns true if the node has a non-empty id
+func (n Node) HasID() bool {
+	return n.ID() != ""
+}
+
+// Set sets a single attribute on the node
+func (n Node) Set(key string, val interface{}) {
+	n[key] = val
+}
+
+// SetAll merges the given attributes into the node
+func (n Node) SetAll(data map[string]interface{}) {
+	for k, v := range data {
+		n[k] = v
+	}
+}
+
+// Get gets an attribute value by key
+func (n Node) Get(key string) interface{} {
+	return n[key]
+}
+
+// GetString gets a string attribute by key(if it exists)
+func (n Node) GetString(key string) string {
+	s, _ := n[key].(string)
+	return s
+}
+
+// GetInt gets an int attribute by key(if it exists)
+func (n Node) GetInt(key string) int {
+	switch v := n[key].(type) {
+	case int:
+		return v
+	case float64:
+		return int(v)
+	}
+	return 0
+}
+
+// GetBool gets a bool attribute by key(if it exists)
+func (n Node) GetBool(key string) bool {
+	b, _ := n[key].(bool)
+	return b
+}
+
+// Del deletes the attribute by key
+func (n Node) Del(key string) {
+	delete(n, key)
+}
+
+// Range iterates over the nodes attributes until the iterator returns false
+func (n Node) Range(fn func(key string, value interface{}) bool) {
+	for k, v := range n {
+		if !fn(k, v) {
+			return
+		}
+	}
+}
+
+// JSON returns the node as JSON bytes
+func (n Node) JSON() ([]byte, error) {
+	return json.Marshal(map[string]interface{}(n))
+}
+
+// FromJSON merges the given JSON bytes into the node's attributes
+func (n Node) FromJSON(bits []byte) error {
+	var data map[string]interface{}
+	if err := json.Unmarshal(bits, &data); err != nil {
+		return err
+	}
+	n.SetAll(data)
+	return nil
+}
@@ -0,0 +1,26 @@
+package primitive
+
+// Type is the type of a node or the relationship type of an edge (ex: "user", "follows")
+type Type string
+
+// String returns the string representation of the type
+func (t Type) String() string {
+	return string(t)
+}
+
+const (
+	// TYPE_KEY is the attribute key holding a node/edge's type
+	TYPE_KEY = "_type"
+	// ID_KEY is the attribute key holding a node/edge's id
+	ID_KEY = "_id"
+)
+
+// TypedID is a unique identifier within the graph - every node and edge implements it
+type TypedID interface {
+	// Type is the type of node/edge this id belongs to
+	Type() Type
+	// ID is the unique identifier of the node/edge (unique among others of the same type)
+	ID() string
+	// HasID returns true if the id is non-empty
+	HasID() bool
+}
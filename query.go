@@ -0,0 +1,242 @@
+package dagger
+
+import (
+	"fmt"
+	"regexp"
+	"strings"
+
+	"github.com/autom8ter/dagger/primitive"
+)
+
+// Result is the output of a Query
+type Result interface {
+	// Rows returns every matched row, one map of return-alias to node attributes per row
+	Rows() []map[string]interface{}
+	// ForEach streams matched rows, stopping early if fn returns false
+	ForEach(fn func(row map[string]interface{}) bool)
+}
+
+type result struct {
+	rows []map[string]interface{}
+}
+
+func (r *result) Rows() []map[string]interface{} {
+	return r.rows
+}
+
+func (r *result) ForEach(fn func(row map[string]interface{}) bool) {
+	for _, row := range r.rows {
+		if !fn(row) {
+			return
+		}
+	}
+}
+
+// Query runs a small Cypher-inspired pattern against the default graph, eg:
+//
+//	MATCH (u:user)-[:follows]->(f:user)-[:posted]->(p:post) WHERE u.id = $id RETURN p
+func Query(pattern string, params map[string]interface{}) (Result, error) {
+	return globalGraph.Query(pattern, params)
+}
+
+// Query runs a small Cypher-inspired pattern against the graph. The planner starts from an indexed GetNode lookup
+// when the pattern's first node is bound by a "alias.id = $param" WHERE clause, falling back to a type scan
+// otherwise, then expands hop by hop via EdgesFrom, applying WHERE predicates as it goes.
+func (g *Graph) Query(pattern string, params map[string]interface{}) (Result, error) {
+	q, err := parseQuery(pattern)
+	if err != nil {
+		return nil, err
+	}
+	var rows []map[string]interface{}
+
+	startAlias := q.pattern.nodes[0].alias
+	startType := q.pattern.nodes[0].typ
+	startFilters := filtersFor(q.wheres, startAlias)
+
+	visit := func(node primitive.Node) bool {
+		if !matchesFilters(startFilters, node, params) {
+			return true
+		}
+		bindings := map[string]primitive.Node{startAlias: node}
+		if err := g.expand(q, 0, bindings, params, &rows); err != nil {
+			return false
+		}
+		return true
+	}
+
+	if id, ok := boundID(startFilters, params); ok {
+		if node, ok := g.GetNode(typedID{typ: startType, id: id}); ok {
+			visit(node)
+		}
+	} else {
+		g.Range(startType, visit)
+	}
+	return &result{rows: rows}, nil
+}
+
+// expand walks pattern hop idx, binding the next node and recursing until every hop has been matched
+func (g *Graph) expand(q *query, idx int, bindings map[string]primitive.Node, params map[string]interface{}, out *[]map[string]interface{}) error {
+	if idx == len(q.pattern.hops) {
+		row := map[string]interface{}{}
+		for _, alias := range q.returns {
+			node, ok := bindings[alias]
+			if !ok {
+				return fmt.Errorf("dagger: query: RETURN references unbound alias %q", alias)
+			}
+			row[alias] = map[string]interface{}(node)
+		}
+		*out = append(*out, row)
+		return nil
+	}
+
+	from := bindings[q.pattern.nodes[idx].alias]
+	to := q.pattern.nodes[idx+1]
+	filters := filtersFor(q.wheres, to.alias)
+	var innerErr error
+	g.EdgesFrom(primitive.Type(q.pattern.hops[idx]), from, func(e *primitive.Edge) bool {
+		peer := e.To
+		if to.typ != "" && peer.Type() != to.typ {
+			return true
+		}
+		peerNode, ok := g.GetNode(peer)
+		if !ok || !matchesFilters(filters, peerNode, params) {
+			return true
+		}
+		next := make(map[string]primitive.Node, len(bindings)+1)
+		for k, v := range bindings {
+			next[k] = v
+		}
+		next[to.alias] = peerNode
+		if err := g.expand(q, idx+1, next, params, out); err != nil {
+			innerErr = err
+			return false
+		}
+		return true
+	})
+	return innerErr
+}
+
+// patternNode is a single "(alias:type)" node in a MATCH pattern
+type patternNode struct {
+	alias string
+	typ   primitive.Type
+}
+
+// pattern is a chain of nodes connected by directed, typed hops: nodes[i] -[hops[i]]-> nodes[i+1]
+type pattern struct {
+	nodes []patternNode
+	hops  []string
+}
+
+// whereClause is a single "alias.attr = $param" predicate
+type whereClause struct {
+	alias string
+	attr  string
+	param string
+}
+
+type query struct {
+	pattern pattern
+	wheres  []whereClause
+	returns []string
+}
+
+var (
+	patternNodeRe = regexp.MustCompile(`\(\s*(\w+)\s*(?::\s*(\w+)\s*)?\)`)
+	patternHopRe  = regexp.MustCompile(`-\[\s*:\s*(\w+)\s*\]->`)
+	whereClauseRe = regexp.MustCompile(`(\w+)\.(\w+)\s*=\s*\$(\w+)`)
+)
+
+// parseQuery parses a "MATCH ... [WHERE ...] RETURN ..." pattern into a query plan
+func parseQuery(q string) (*query, error) {
+	upper := strings.ToUpper(q)
+	matchIdx := strings.Index(upper, "MATCH")
+	returnIdx := strings.Index(upper, "RETURN")
+	if matchIdx == -1 || returnIdx == -1 || returnIdx < matchIdx {
+		return nil, fmt.Errorf("dagger: query: expected a MATCH ... RETURN ... pattern")
+	}
+	whereIdx := strings.Index(upper, "WHERE")
+
+	matchEnd := returnIdx
+	var whereClauseStr string
+	if whereIdx != -1 && whereIdx > matchIdx {
+		matchEnd = whereIdx
+		whereClauseStr = strings.TrimSpace(q[whereIdx+len("WHERE") : returnIdx])
+	}
+	matchClause := strings.TrimSpace(q[matchIdx+len("MATCH") : matchEnd])
+	returnClause := strings.TrimSpace(q[returnIdx+len("RETURN"):])
+
+	nodeMatches := patternNodeRe.FindAllStringSubmatch(matchClause, -1)
+	if len(nodeMatches) == 0 {
+		return nil, fmt.Errorf("dagger: query: MATCH clause has no nodes: %q", matchClause)
+	}
+	hopMatches := patternHopRe.FindAllStringSubmatch(matchClause, -1)
+	if len(hopMatches) != len(nodeMatches)-1 {
+		return nil, fmt.Errorf("dagger: query: MATCH clause has %d nodes but %d hops", len(nodeMatches), len(hopMatches))
+	}
+
+	p := pattern{}
+	for _, m := range nodeMatches {
+		p.nodes = append(p.nodes, patternNode{alias: m[1], typ: primitive.Type(m[2])})
+	}
+	for _, m := range hopMatches {
+		p.hops = append(p.hops, m[1])
+	}
+
+	var wheres []whereClause
+	for _, m := range whereClauseRe.FindAllStringSubmatch(whereClauseStr, -1) {
+		wheres = append(wheres, whereClause{alias: m[1], attr: m[2], param: m[3]})
+	}
+
+	var returns []string
+	for _, alias := range strings.Split(returnClause, ",") {
+		if alias = strings.TrimSpace(alias); alias != "" {
+			returns = append(returns, alias)
+		}
+	}
+	if len(returns) == 0 {
+		return nil, fmt.Errorf("dagger: query: RETURN clause is empty")
+	}
+
+	return &query{pattern: p, wheres: wheres, returns: returns}, nil
+}
+
+func filtersFor(wheres []whereClause, alias string) []whereClause {
+	var matched []whereClause
+	for _, w := range wheres {
+		if w.alias == alias {
+			matched = append(matched, w)
+		}
+	}
+	return matched
+}
+
+// boundID reports whether filters constrain the node to a single id via "alias.id = $param", returning that id
+func boundID(filters []whereClause, params map[string]interface{}) (string, bool) {
+	for _, f := range filters {
+		if f.attr != primitive.ID_KEY && f.attr != "id" {
+			continue
+		}
+		if v, ok := params[f.param]; ok {
+			return fmt.Sprintf("%v", v), true
+		}
+	}
+	return "", false
+}
+
+func matchesFilters(filters []whereClause, node primitive.Node, params map[string]interface{}) bool {
+	for _, f := range filters {
+		want, ok := params[f.param]
+		if !ok {
+			return false
+		}
+		key := f.attr
+		if key == "id" {
+			key = primitive.ID_KEY
+		}
+		if fmt.Sprintf("%v", node.Get(key)) != fmt.Sprintf("%v", want) {
+			return false
+		}
+	}
+	return true
+}
@@ -0,0 +1,44 @@
+package dagger
+
+import (
+	"testing"
+
+	"github.com/autom8ter/dagger/primitive"
+)
+
+// TestQueryOnNonGlobalGraph guards against Query's expand step silently traversing globalGraph instead of the
+// receiver Graph - a bug that made Query always return zero rows for any Graph other than the default one.
+func TestQueryOnNonGlobalGraph(t *testing.T) {
+	g := NewGraph(newMemStore())
+	u1, err := g.NewNode(map[string]interface{}{primitive.TYPE_KEY: "user", primitive.ID_KEY: "u1"})
+	if err != nil {
+		t.Fatalf("NewNode(u1): %v", err)
+	}
+	u2, err := g.NewNode(map[string]interface{}{primitive.TYPE_KEY: "user", primitive.ID_KEY: "u2"})
+	if err != nil {
+		t.Fatalf("NewNode(u2): %v", err)
+	}
+	post, err := g.NewNode(map[string]interface{}{primitive.TYPE_KEY: "post", primitive.ID_KEY: "p1"})
+	if err != nil {
+		t.Fatalf("NewNode(post): %v", err)
+	}
+	if _, err := u1.Connect(u2, "follows", false, nil); err != nil {
+		t.Fatalf("Connect(u1 -> u2): %v", err)
+	}
+	if _, err := u2.Connect(post, "posted", false, nil); err != nil {
+		t.Fatalf("Connect(u2 -> post): %v", err)
+	}
+
+	result, err := g.Query("MATCH (u:user)-[:follows]->(f:user)-[:posted]->(p:post) WHERE u.id = $id RETURN p",
+		map[string]interface{}{"id": "u1"})
+	if err != nil {
+		t.Fatalf("Query: %v", err)
+	}
+	rows := result.Rows()
+	if len(rows) != 1 {
+		t.Fatalf("expected 1 row, got %d", len(rows))
+	}
+	if rows[0]["p"].(map[string]interface{})[primitive.ID_KEY] != "p1" {
+		t.Fatalf("expected p bound to p1, got %v", rows[0]["p"])
+	}
+}
@@ -0,0 +1,98 @@
+package dagger
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/autom8ter/dagger/primitive"
+)
+
+// Cardinality constrains how many edges of a given relationship a node may participate in
+type Cardinality int
+
+const (
+	// ManyToMany places no cardinality constraint on the relationship
+	ManyToMany Cardinality = iota
+	// OneToMany allows a "from" node to connect to many "to" nodes, but each "to" node may only have one such
+	// inbound edge (eg: a user owns many accounts, but each account has one owner)
+	OneToMany
+	// ManyToOne is the inverse of OneToMany: many "from" nodes may connect to a single "to" node, but each "from"
+	// node may only have one such outbound edge
+	ManyToOne
+	// OneToOne allows at most one edge of the relationship per node, on either side
+	OneToOne
+)
+
+// relationshipPolicy constrains which node types a relationship may connect, and its cardinality
+type relationshipPolicy struct {
+	from        primitive.Type
+	to          primitive.Type
+	cardinality Cardinality
+}
+
+var (
+	relationshipMu sync.RWMutex
+	relationships  = map[string]relationshipPolicy{}
+)
+
+// RegisterRelationship constrains a relationship so that it may only connect a "from" node to a "to" node, enforced
+// with the given Cardinality. Connect rejects edges that violate a registered relationship's policy.
+func RegisterRelationship(from, to primitive.Type, relationship string, cardinality Cardinality) {
+	relationshipMu.Lock()
+	defer relationshipMu.Unlock()
+	relationships[relationship] = relationshipPolicy{from: from, to: to, cardinality: cardinality}
+}
+
+func relationshipFor(relationship string) (relationshipPolicy, bool) {
+	relationshipMu.RLock()
+	defer relationshipMu.RUnlock()
+	policy, ok := relationships[relationship]
+	return policy, ok
+}
+
+// checkRelationship enforces a registered relationship's type and cardinality constraints before an edge is created
+func checkRelationship(from, to *Node, relationship string) error {
+	policy, ok := relationshipFor(relationship)
+	if !ok {
+		return nil
+	}
+	if policy.from != from.Type() || policy.to != to.Type() {
+		return fmt.Errorf("dagger: relationship %q requires %s -> %s, got %s -> %s", relationship, policy.from, policy.to, from.Type(), to.Type())
+	}
+	switch policy.cardinality {
+	case OneToMany:
+		if hasInboundEdge(primitive.Type(relationship), to) {
+			return fmt.Errorf("dagger: relationship %q is one-to-many: %s %s already has an owner", relationship, to.Type(), to.ID())
+		}
+	case ManyToOne:
+		if hasOutboundEdge(primitive.Type(relationship), from) {
+			return fmt.Errorf("dagger: relationship %q is many-to-one: %s %s already points somewhere", relationship, from.Type(), from.ID())
+		}
+	case OneToOne:
+		if hasOutboundEdge(primitive.Type(relationship), from) {
+			return fmt.Errorf("dagger: relationship %q is one-to-one: %s %s is already connected", relationship, from.Type(), from.ID())
+		}
+		if hasInboundEdge(primitive.Type(relationship), to) {
+			return fmt.Errorf("dagger: relationship %q is one-to-one: %s %s is already connected", relationship, to.Type(), to.ID())
+		}
+	}
+	return nil
+}
+
+func hasInboundEdge(edgeType primitive.Type, n *Node) bool {
+	found := false
+	n.EdgesTo(edgeType, func(*Edge) bool {
+		found = true
+		return false
+	})
+	return found
+}
+
+func hasOutboundEdge(edgeType primitive.Type, n *Node) bool {
+	found := false
+	n.EdgesFrom(edgeType, func(*Edge) bool {
+		found = true
+		return false
+	})
+	return found
+}
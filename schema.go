@@ -0,0 +1,128 @@
+package dagger
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/autom8ter/dagger/primitive"
+)
+
+// AttrType is the expected Go type of a schema attribute
+type AttrType int
+
+const (
+	AttrAny AttrType = iota
+	AttrString
+	AttrInt
+	AttrBool
+	AttrFloat
+)
+
+// AttrSchema declares the constraints on a single node/edge attribute
+type AttrSchema struct {
+	// Type is the attribute's expected type. AttrAny skips the type check.
+	Type AttrType
+	// Required rejects nodes/edges missing this attribute entirely
+	Required bool
+	// Validate, if set, is run against the attribute's value after the type check passes
+	Validate func(value interface{}) error
+}
+
+// Schema declares the attributes a node/edge type must conform to
+type Schema struct {
+	Attributes map[string]AttrSchema
+}
+
+func (s Schema) validate(attrs map[string]interface{}) error {
+	for key, attr := range s.Attributes {
+		val, ok := attrs[key]
+		if !ok {
+			if attr.Required {
+				return fmt.Errorf("dagger: missing required attribute %q", key)
+			}
+			continue
+		}
+		if err := attr.checkType(val); err != nil {
+			return fmt.Errorf("dagger: attribute %q: %w", key, err)
+		}
+		if attr.Validate != nil {
+			if err := attr.Validate(val); err != nil {
+				return fmt.Errorf("dagger: attribute %q: %w", key, err)
+			}
+		}
+	}
+	return nil
+}
+
+func (a AttrSchema) checkType(val interface{}) error {
+	switch a.Type {
+	case AttrString:
+		if _, ok := val.(string); !ok {
+			return fmt.Errorf("expected a string, got %T", val)
+		}
+	case AttrInt:
+		switch val.(type) {
+		case int, float64:
+		default:
+			return fmt.Errorf("expected an int, got %T", val)
+		}
+	case AttrBool:
+		if _, ok := val.(bool); !ok {
+			return fmt.Errorf("expected a bool, got %T", val)
+		}
+	case AttrFloat:
+		if _, ok := val.(float64); !ok {
+			return fmt.Errorf("expected a float, got %T", val)
+		}
+	}
+	return nil
+}
+
+var (
+	schemaMu sync.RWMutex
+	schemas  = map[primitive.Type]Schema{}
+)
+
+// RegisterType registers the Schema that attributes of nodes/edges of the given type must conform to. A relationship
+// name doubles as an edge's type, so the same registry constrains both.
+// NewNode, (*Node).Patch, and (*Node).FromJSON reject data that violates a registered node schema; (*Edge).Patch
+// rejects data that violates a registered edge/relationship schema.
+func RegisterType(typeName string, schema Schema) {
+	schemaMu.Lock()
+	defer schemaMu.Unlock()
+	schemas[primitive.Type(typeName)] = schema
+}
+
+func validateAttributes(nodeType primitive.Type, attrs map[string]interface{}) error {
+	schemaMu.RLock()
+	schema, ok := schemas[nodeType]
+	schemaMu.RUnlock()
+	if !ok {
+		return nil
+	}
+	return schema.validate(attrs)
+}
+
+// Validate scans every node in the default graph and reports every schema violation found
+func Validate() []error {
+	return globalGraph.Validate()
+}
+
+// Validate scans every node and edge in the graph against its registered Schema(if any) and reports every
+// violation found
+func (g *Graph) Validate() []error {
+	var errs []error
+	g.Range("", func(node primitive.Node) bool {
+		if err := validateAttributes(node.Type(), node); err != nil {
+			errs = append(errs, fmt.Errorf("dagger: node %s %s: %w", node.Type(), node.ID(), err))
+		}
+		g.EdgesFrom("", node, func(e *primitive.Edge) bool {
+			if err := validateAttributes(e.Type(), e.Node); err != nil {
+				errs = append(errs, fmt.Errorf("dagger: edge %s %s: %w", e.Type(), e.ID(), err))
+			}
+			return true
+		})
+		return true
+	})
+	return errs
+}
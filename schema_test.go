@@ -0,0 +1,144 @@
+package dagger
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/autom8ter/dagger/primitive"
+)
+
+// TestRegisterTypeRejectsNewNode verifies that NewNode rejects attributes that violate a registered Schema.
+func TestRegisterTypeRejectsNewNode(t *testing.T) {
+	RegisterType("schema_widget", Schema{Attributes: map[string]AttrSchema{
+		"name": {Type: AttrString, Required: true},
+	}})
+
+	if _, err := NewNode(map[string]interface{}{primitive.TYPE_KEY: "schema_widget"}); err == nil {
+		t.Fatalf("expected NewNode to reject a widget missing the required \"name\" attribute")
+	}
+	if _, err := NewNode(map[string]interface{}{primitive.TYPE_KEY: "schema_widget", "name": 5}); err == nil {
+		t.Fatalf("expected NewNode to reject a widget whose \"name\" is not a string")
+	}
+	widget, err := NewNode(map[string]interface{}{primitive.TYPE_KEY: "schema_widget", "name": "gadget"})
+	if err != nil {
+		t.Fatalf("NewNode: %v", err)
+	}
+	if widget.GetString("name") != "gadget" {
+		t.Fatalf("expected name %q, got %q", "gadget", widget.GetString("name"))
+	}
+}
+
+// TestRegisterTypeRejectsPatch verifies that Patch re-validates the merged attributes against the registered Schema.
+func TestRegisterTypeRejectsPatch(t *testing.T) {
+	RegisterType("schema_gizmo", Schema{Attributes: map[string]AttrSchema{
+		"count": {Type: AttrInt, Required: true},
+	}})
+	gizmo, err := NewNode(map[string]interface{}{primitive.TYPE_KEY: "schema_gizmo", "count": 1})
+	if err != nil {
+		t.Fatalf("NewNode: %v", err)
+	}
+	if err := gizmo.Patch(map[string]interface{}{"count": "not-a-number"}); err == nil {
+		t.Fatalf("expected Patch to reject a non-int \"count\"")
+	}
+	if err := gizmo.Patch(map[string]interface{}{"count": 2}); err != nil {
+		t.Fatalf("Patch: %v", err)
+	}
+	if gizmo.GetInt("count") != 2 {
+		t.Fatalf("expected count 2, got %d", gizmo.GetInt("count"))
+	}
+}
+
+// TestRegisterTypeRejectsEdgePatch verifies that a Schema registered under a relationship name constrains the edge's
+// own attributes, not just the nodes it connects.
+func TestRegisterTypeRejectsEdgePatch(t *testing.T) {
+	RegisterType("schema_employs", Schema{Attributes: map[string]AttrSchema{
+		"since": {Type: AttrInt, Required: true},
+	}})
+	employer, err := NewNode(map[string]interface{}{primitive.TYPE_KEY: "schema_employer", primitive.ID_KEY: "acme"})
+	if err != nil {
+		t.Fatalf("NewNode(employer): %v", err)
+	}
+	employee, err := NewNode(map[string]interface{}{primitive.TYPE_KEY: "schema_employee", primitive.ID_KEY: "e1"})
+	if err != nil {
+		t.Fatalf("NewNode(employee): %v", err)
+	}
+	edge, err := employer.Connect(employee, "schema_employs", false, nil)
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+	if err := edge.Patch(map[string]interface{}{"since": "not-a-year"}); err == nil {
+		t.Fatalf("expected Patch to reject a non-int \"since\"")
+	}
+	if err := edge.Patch(map[string]interface{}{"since": 2020}); err != nil {
+		t.Fatalf("Patch: %v", err)
+	}
+	if edge.GetInt("since") != 2020 {
+		t.Fatalf("expected since 2020, got %d", edge.GetInt("since"))
+	}
+}
+
+// TestRegisterRelationshipRejectsCardinality verifies that Connect enforces a registered relationship's cardinality.
+func TestRegisterRelationshipRejectsCardinality(t *testing.T) {
+	RegisterRelationship("schema_user", "schema_account", "schema_owns", OneToMany)
+
+	owner1, err := NewNode(map[string]interface{}{primitive.TYPE_KEY: "schema_user", primitive.ID_KEY: "u1"})
+	if err != nil {
+		t.Fatalf("NewNode(owner1): %v", err)
+	}
+	owner2, err := NewNode(map[string]interface{}{primitive.TYPE_KEY: "schema_user", primitive.ID_KEY: "u2"})
+	if err != nil {
+		t.Fatalf("NewNode(owner2): %v", err)
+	}
+	account, err := NewNode(map[string]interface{}{primitive.TYPE_KEY: "schema_account", primitive.ID_KEY: "a1"})
+	if err != nil {
+		t.Fatalf("NewNode(account): %v", err)
+	}
+
+	if _, err := owner1.Connect(account, "schema_owns", false, nil); err != nil {
+		t.Fatalf("Connect(owner1 -> account): %v", err)
+	}
+	if _, err := owner2.Connect(account, "schema_owns", false, nil); err == nil {
+		t.Fatalf("expected Connect(owner2 -> account) to fail: account already has an owner")
+	}
+}
+
+// TestValidateReportsExistingViolations verifies that Validate audits nodes and edges that already violated a Schema
+// registered after they were created, since NewNode/Patch only enforce a Schema at write time.
+func TestValidateReportsExistingViolations(t *testing.T) {
+	node, err := NewNode(map[string]interface{}{primitive.TYPE_KEY: "schema_late_node", primitive.ID_KEY: "n1"})
+	if err != nil {
+		t.Fatalf("NewNode(node): %v", err)
+	}
+	peer, err := NewNode(map[string]interface{}{primitive.TYPE_KEY: "schema_late_node", primitive.ID_KEY: "n2"})
+	if err != nil {
+		t.Fatalf("NewNode(peer): %v", err)
+	}
+	edge, err := node.Connect(peer, "schema_late_edge", false, nil)
+	if err != nil {
+		t.Fatalf("Connect: %v", err)
+	}
+
+	RegisterType("schema_late_node", Schema{Attributes: map[string]AttrSchema{
+		"label": {Type: AttrString, Required: true},
+	}})
+	RegisterType("schema_late_edge", Schema{Attributes: map[string]AttrSchema{
+		"weight": {Type: AttrInt, Required: true},
+	}})
+
+	errs := Validate()
+	var sawNode, sawEdge bool
+	for _, e := range errs {
+		if strings.Contains(e.Error(), "n1") {
+			sawNode = true
+		}
+		if strings.Contains(e.Error(), string(edge.Type())) {
+			sawEdge = true
+		}
+	}
+	if !sawNode {
+		t.Fatalf("expected Validate to report schema_late_node %q as missing its required \"label\", got %v", "n1", errs)
+	}
+	if !sawEdge {
+		t.Fatalf("expected Validate to report the schema_late_edge edge as missing its required \"weight\", got %v", errs)
+	}
+}
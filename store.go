@@ -0,0 +1,63 @@
+package dagger
+
+import (
+	"strings"
+
+	"github.com/autom8ter/dagger/primitive"
+)
+
+// Store persists the nodes and edges of a graph so that it can survive process restarts.
+// Implementations must be safe for concurrent use.
+type Store interface {
+	// PutNode creates or overwrites a node's attributes
+	PutNode(node primitive.Node) error
+	// GetNode fetches a node's attributes by id
+	GetNode(id primitive.TypedID) (primitive.Node, bool, error)
+	// DeleteNode removes a node and the edge index kept under it. The edges themselves, and their entries in
+	// peer nodes' indexes, are not removed.
+	DeleteNode(id primitive.TypedID) error
+	// RangeNodes streams every node of the given type, stopping early if fn returns false.
+	// An empty nodeType ranges over every node in the store.
+	RangeNodes(nodeType primitive.Type, fn func(primitive.Node) bool) error
+
+	// PutEdge indexes an edge under both its From and To nodes
+	PutEdge(edge *primitive.Edge) error
+	// DeleteEdge removes an edge from both endpoints' indexes
+	DeleteEdge(edge *primitive.Edge) error
+	// EdgesFrom streams edges of the given type that originate at "from", stopping early if fn returns false.
+	EdgesFrom(edgeType primitive.Type, from primitive.TypedID, fn func(*primitive.Edge) bool) error
+	// EdgesTo streams edges of the given type that terminate at "to", stopping early if fn returns false.
+	EdgesTo(edgeType primitive.Type, to primitive.TypedID, fn func(*primitive.Edge) bool) error
+
+	// Close releases any resources held by the store
+	Close() error
+}
+
+// key renders a type|id tuple used as the store's primary node/edge key
+func key(id primitive.TypedID) string {
+	return string(id.Type()) + "|" + id.ID()
+}
+
+// edgeIndexKey renders the relationship|peerType|peerID tuple used to index an edge under a node
+func edgeIndexKey(relationship primitive.Type, peer primitive.TypedID) string {
+	return string(relationship) + "|" + string(peer.Type()) + "|" + peer.ID()
+}
+
+// typedID is a minimal primitive.TypedID, used to address a node by type/id alone
+type typedID struct {
+	typ primitive.Type
+	id  string
+}
+
+func (t typedID) Type() primitive.Type { return t.typ }
+func (t typedID) ID() string           { return t.id }
+func (t typedID) HasID() bool          { return t.id != "" }
+
+// parseKey parses a "type|id" tuple(as rendered by key) back into a TypedID
+func parseKey(s string) primitive.TypedID {
+	parts := strings.SplitN(s, "|", 2)
+	if len(parts) != 2 {
+		return typedID{}
+	}
+	return typedID{typ: primitive.Type(parts[0]), id: parts[1]}
+}
@@ -0,0 +1,213 @@
+package dagger
+
+import (
+	"encoding/json"
+	"strings"
+
+	"github.com/autom8ter/dagger/primitive"
+	bolt "go.etcd.io/bbolt"
+)
+
+// Bucket layout, modeled after lnd's channeldb graph store:
+//
+//	nodes                         type|id -> node attributes(JSON)
+//	edges                         relationship|id -> edge(JSON, includes From/To)
+//	node-edges/<type|id>/out      relationship|peerType|peerID -> edge id
+//	node-edges/<type|id>/in       relationship|peerType|peerID -> edge id
+var (
+	nodesBucket     = []byte("nodes")
+	edgesBucket     = []byte("edges")
+	nodeEdgesBucket = []byte("node-edges")
+	outBucket       = []byte("out")
+	inBucket        = []byte("in")
+)
+
+// boltStore is a Store backed by a boltdb/bbolt file on disk, allowing a graph to survive restarts.
+type boltStore struct {
+	db *bolt.DB
+}
+
+// newBoltStore opens (creating if necessary) a bolt-backed Store at path
+func newBoltStore(path string) (*boltStore, error) {
+	db, err := bolt.Open(path, 0600, nil)
+	if err != nil {
+		return nil, err
+	}
+	err = db.Update(func(tx *bolt.Tx) error {
+		for _, b := range [][]byte{nodesBucket, edgesBucket, nodeEdgesBucket} {
+			if _, err := tx.CreateBucketIfNotExists(b); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+	if err != nil {
+		db.Close()
+		return nil, err
+	}
+	return &boltStore{db: db}, nil
+}
+
+func (b *boltStore) PutNode(node primitive.Node) error {
+	bits, err := node.JSON()
+	if err != nil {
+		return err
+	}
+	return b.db.Update(func(tx *bolt.Tx) error {
+		return tx.Bucket(nodesBucket).Put([]byte(key(node)), bits)
+	})
+}
+
+func (b *boltStore) GetNode(id primitive.TypedID) (primitive.Node, bool, error) {
+	var node primitive.Node
+	err := b.db.View(func(tx *bolt.Tx) error {
+		bits := tx.Bucket(nodesBucket).Get([]byte(key(id)))
+		if bits == nil {
+			return nil
+		}
+		node = primitive.Node{}
+		return node.FromJSON(bits)
+	})
+	return node, node != nil, err
+}
+
+func (b *boltStore) DeleteNode(id primitive.TypedID) error {
+	k := []byte(key(id))
+	return b.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(nodesBucket).Delete(k); err != nil {
+			return err
+		}
+		if nb := tx.Bucket(nodeEdgesBucket); nb != nil {
+			if err := nb.DeleteBucket(k); err != nil && err != bolt.ErrBucketNotFound {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (b *boltStore) RangeNodes(nodeType primitive.Type, fn func(primitive.Node) bool) error {
+	return b.db.View(func(tx *bolt.Tx) error {
+		c := tx.Bucket(nodesBucket).Cursor()
+		prefix := []byte(string(nodeType) + "|")
+		k, v := c.First()
+		if nodeType != "" {
+			k, v = c.Seek(prefix)
+		}
+		for ; k != nil; k, v = c.Next() {
+			if nodeType != "" && !strings.HasPrefix(string(k), string(prefix)) {
+				break
+			}
+			node := primitive.Node{}
+			if err := node.FromJSON(v); err != nil {
+				return err
+			}
+			if !fn(node) {
+				return nil
+			}
+		}
+		return nil
+	})
+}
+
+func (b *boltStore) PutEdge(edge *primitive.Edge) error {
+	bits, err := json.Marshal(edge)
+	if err != nil {
+		return err
+	}
+	edgeID := []byte(edge.ID())
+	return b.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(edgesBucket).Put([]byte(key(edge)), bits); err != nil {
+			return err
+		}
+		out, err := nodeEdgeBucket(tx, edge.From, outBucket)
+		if err != nil {
+			return err
+		}
+		if err := out.Put([]byte(edgeIndexKey(edge.Type(), edge.To)), edgeID); err != nil {
+			return err
+		}
+		in, err := nodeEdgeBucket(tx, edge.To, inBucket)
+		if err != nil {
+			return err
+		}
+		return in.Put([]byte(edgeIndexKey(edge.Type(), edge.From)), edgeID)
+	})
+}
+
+func (b *boltStore) DeleteEdge(edge *primitive.Edge) error {
+	return b.db.Update(func(tx *bolt.Tx) error {
+		if err := tx.Bucket(edgesBucket).Delete([]byte(key(edge))); err != nil {
+			return err
+		}
+		if out, err := nodeEdgeBucket(tx, edge.From, outBucket); err == nil {
+			if err := out.Delete([]byte(edgeIndexKey(edge.Type(), edge.To))); err != nil {
+				return err
+			}
+		}
+		if in, err := nodeEdgeBucket(tx, edge.To, inBucket); err == nil {
+			if err := in.Delete([]byte(edgeIndexKey(edge.Type(), edge.From))); err != nil {
+				return err
+			}
+		}
+		return nil
+	})
+}
+
+func (b *boltStore) EdgesFrom(edgeType primitive.Type, from primitive.TypedID, fn func(*primitive.Edge) bool) error {
+	return b.rangeEdgeIndex(outBucket, edgeType, from, fn)
+}
+
+func (b *boltStore) EdgesTo(edgeType primitive.Type, to primitive.TypedID, fn func(*primitive.Edge) bool) error {
+	return b.rangeEdgeIndex(inBucket, edgeType, to, fn)
+}
+
+func (b *boltStore) rangeEdgeIndex(direction []byte, edgeType primitive.Type, node primitive.TypedID, fn func(*primitive.Edge) bool) error {
+	return b.db.View(func(tx *bolt.Tx) error {
+		nb := tx.Bucket(nodeEdgesBucket).Bucket([]byte(key(node)))
+		if nb == nil {
+			return nil
+		}
+		dir := nb.Bucket(direction)
+		if dir == nil {
+			return nil
+		}
+		prefix := []byte(string(edgeType) + "|")
+		c := dir.Cursor()
+		k, v := c.First()
+		if edgeType != "" {
+			k, v = c.Seek(prefix)
+		}
+		for ; k != nil; k, v = c.Next() {
+			if edgeType != "" && !strings.HasPrefix(string(k), string(prefix)) {
+				break
+			}
+			relationship := strings.SplitN(string(k), "|", 2)[0]
+			bits := tx.Bucket(edgesBucket).Get([]byte(relationship + "|" + string(v)))
+			if bits == nil {
+				continue
+			}
+			edge := &primitive.Edge{}
+			if err := json.Unmarshal(bits, edge); err != nil {
+				return err
+			}
+			if !fn(edge) {
+				return nil
+			}
+		}
+		return nil
+	})
+}
+
+func (b *boltStore) Close() error {
+	return b.db.Close()
+}
+
+// nodeEdgeBucket returns (creating if necessary) the out/in sub-bucket for the given node
+func nodeEdgeBucket(tx *bolt.Tx, node primitive.TypedID, direction []byte) (*bolt.Bucket, error) {
+	nb, err := tx.Bucket(nodeEdgesBucket).CreateBucketIfNotExists([]byte(key(node)))
+	if err != nil {
+		return nil, err
+	}
+	return nb.CreateBucketIfNotExists(direction)
+}
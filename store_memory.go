@@ -0,0 +1,123 @@
+package dagger
+
+import (
+	"sync"
+
+	"github.com/autom8ter/dagger/primitive"
+)
+
+// memStore is the default, in-memory Store implementation. Nothing is persisted to disk.
+type memStore struct {
+	mu        sync.RWMutex
+	nodes     map[string]primitive.Node
+	edgesFrom map[string]map[string]*primitive.Edge
+	edgesTo   map[string]map[string]*primitive.Edge
+}
+
+// newMemStore creates an empty in-memory Store
+func newMemStore() *memStore {
+	return &memStore{
+		nodes:     map[string]primitive.Node{},
+		edgesFrom: map[string]map[string]*primitive.Edge{},
+		edgesTo:   map[string]map[string]*primitive.Edge{},
+	}
+}
+
+func (m *memStore) PutNode(node primitive.Node) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	m.nodes[key(node)] = node
+	return nil
+}
+
+func (m *memStore) GetNode(id primitive.TypedID) (primitive.Node, bool, error) {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	node, ok := m.nodes[key(id)]
+	return node, ok, nil
+}
+
+func (m *memStore) DeleteNode(id primitive.TypedID) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	k := key(id)
+	delete(m.nodes, k)
+	delete(m.edgesFrom, k)
+	delete(m.edgesTo, k)
+	return nil
+}
+
+func (m *memStore) RangeNodes(nodeType primitive.Type, fn func(primitive.Node) bool) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, node := range m.nodes {
+		if nodeType != "" && node.Type() != nodeType {
+			continue
+		}
+		if !fn(node) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m *memStore) PutEdge(edge *primitive.Edge) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	fromKey := key(edge.From)
+	if m.edgesFrom[fromKey] == nil {
+		m.edgesFrom[fromKey] = map[string]*primitive.Edge{}
+	}
+	m.edgesFrom[fromKey][edgeIndexKey(edge.Type(), edge.To)] = edge
+
+	toKey := key(edge.To)
+	if m.edgesTo[toKey] == nil {
+		m.edgesTo[toKey] = map[string]*primitive.Edge{}
+	}
+	m.edgesTo[toKey][edgeIndexKey(edge.Type(), edge.From)] = edge
+	return nil
+}
+
+func (m *memStore) DeleteEdge(edge *primitive.Edge) error {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+	if from, ok := m.edgesFrom[key(edge.From)]; ok {
+		delete(from, edgeIndexKey(edge.Type(), edge.To))
+	}
+	if to, ok := m.edgesTo[key(edge.To)]; ok {
+		delete(to, edgeIndexKey(edge.Type(), edge.From))
+	}
+	return nil
+}
+
+func (m *memStore) EdgesFrom(edgeType primitive.Type, from primitive.TypedID, fn func(*primitive.Edge) bool) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, edge := range m.edgesFrom[key(from)] {
+		if edgeType != "" && edge.Type() != edgeType {
+			continue
+		}
+		if !fn(edge) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m *memStore) EdgesTo(edgeType primitive.Type, to primitive.TypedID, fn func(*primitive.Edge) bool) error {
+	m.mu.RLock()
+	defer m.mu.RUnlock()
+	for _, edge := range m.edgesTo[key(to)] {
+		if edgeType != "" && edge.Type() != edgeType {
+			continue
+		}
+		if !fn(edge) {
+			return nil
+		}
+	}
+	return nil
+}
+
+func (m *memStore) Close() error {
+	return nil
+}
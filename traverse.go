@@ -0,0 +1,190 @@
+package dagger
+
+import (
+	"container/heap"
+	"errors"
+
+	"github.com/autom8ter/dagger/primitive"
+)
+
+// ErrNodeNotFound is returned when a traversal is asked to reach a node that doesn't exist in the graph
+var ErrNodeNotFound = errors.New("dagger: node not found")
+
+// ErrPathNotFound is returned by ShortestPath when the target node is unreachable from the source
+var ErrPathNotFound = errors.New("dagger: no path to target node")
+
+// EdgeFilter decides whether a traversal should walk the given edge. Returning false skips it.
+type EdgeFilter func(e *Edge) bool
+
+// BFS walks the graph breadth-first starting at n, following edges of the given type up to maxDepth hops.
+// visit is called with the path of edges taken to reach each node and the node itself; returning false stops the traversal early.
+// A maxDepth <= 0 means unbounded.
+func (n *Node) BFS(edgeType primitive.Type, maxDepth int, visit func(path []*Edge, node *Node) bool) {
+	n.FilterBFS(edgeType, maxDepth, nil, visit)
+}
+
+// FilterBFS is BFS constrained by filter: edges for which filter returns false are not traversed. A nil filter
+// behaves exactly like BFS.
+func (n *Node) FilterBFS(edgeType primitive.Type, maxDepth int, filter EdgeFilter, visit func(path []*Edge, node *Node) bool) {
+	type frame struct {
+		node *Node
+		path []*Edge
+	}
+	visited := map[string]bool{key(n): true}
+	queue := []frame{{node: n}}
+	for len(queue) > 0 {
+		cur := queue[0]
+		queue = queue[1:]
+		if maxDepth > 0 && len(cur.path) >= maxDepth {
+			continue
+		}
+		stop := false
+		cur.node.EdgesFrom(edgeType, func(e *Edge) bool {
+			if filter != nil && !filter(e) {
+				return true
+			}
+			peer := e.To()
+			if visited[key(peer)] {
+				return true
+			}
+			visited[key(peer)] = true
+			path := append(append([]*Edge{}, cur.path...), e)
+			if !visit(path, peer) {
+				stop = true
+				return false
+			}
+			queue = append(queue, frame{node: peer, path: path})
+			return true
+		})
+		if stop {
+			return
+		}
+	}
+}
+
+// DFS walks the graph depth-first starting at n, following edges of the given type up to maxDepth hops.
+// visit is called with the path of edges taken to reach each node and the node itself; returning false stops the traversal early.
+// A maxDepth <= 0 means unbounded.
+func (n *Node) DFS(edgeType primitive.Type, maxDepth int, visit func(path []*Edge, node *Node) bool) {
+	n.FilterDFS(edgeType, maxDepth, nil, visit)
+}
+
+// FilterDFS is DFS constrained by filter: edges for which filter returns false are not traversed. A nil filter
+// behaves exactly like DFS.
+func (n *Node) FilterDFS(edgeType primitive.Type, maxDepth int, filter EdgeFilter, visit func(path []*Edge, node *Node) bool) {
+	visited := map[string]bool{key(n): true}
+	var walk func(node *Node, path []*Edge) bool
+	walk = func(node *Node, path []*Edge) bool {
+		if maxDepth > 0 && len(path) >= maxDepth {
+			return true
+		}
+		cont := true
+		node.EdgesFrom(edgeType, func(e *Edge) bool {
+			if filter != nil && !filter(e) {
+				return true
+			}
+			peer := e.To()
+			if visited[key(peer)] {
+				return true
+			}
+			visited[key(peer)] = true
+			next := append(append([]*Edge{}, path...), e)
+			if !visit(next, peer) {
+				cont = false
+				return false
+			}
+			if !walk(peer, next) {
+				cont = false
+				return false
+			}
+			return true
+		})
+		return cont
+	}
+	walk(n, nil)
+}
+
+// pqItem is an entry in ShortestPath's priority queue
+type pqItem struct {
+	node  *Node
+	dist  float64
+	path  []*Edge
+	index int
+}
+
+// priorityQueue is a container/heap min-heap ordered by pqItem.dist
+type priorityQueue []*pqItem
+
+func (pq priorityQueue) Len() int           { return len(pq) }
+func (pq priorityQueue) Less(i, j int) bool { return pq[i].dist < pq[j].dist }
+func (pq priorityQueue) Swap(i, j int) {
+	pq[i], pq[j] = pq[j], pq[i]
+	pq[i].index, pq[j].index = i, j
+}
+func (pq *priorityQueue) Push(x interface{}) {
+	item := x.(*pqItem)
+	item.index = len(*pq)
+	*pq = append(*pq, item)
+}
+func (pq *priorityQueue) Pop() interface{} {
+	old := *pq
+	n := len(old)
+	item := old[n-1]
+	*pq = old[:n-1]
+	return item
+}
+
+// ShortestPath finds the lowest-weight path from n to target by following edges of the given type, using Dijkstra's
+// algorithm. weight extracts a non-negative cost from each candidate edge; a nil weight treats every edge as cost 1.
+// It returns ErrNodeNotFound if target doesn't exist and ErrPathNotFound if target is unreachable from n.
+func (n *Node) ShortestPath(target primitive.TypedID, edgeType primitive.Type, weight func(*Edge) float64) ([]*Edge, float64, error) {
+	return n.FilterShortestPath(target, edgeType, nil, weight)
+}
+
+// FilterShortestPath is ShortestPath constrained by filter: edges for which filter returns false are not considered.
+// A nil filter behaves exactly like ShortestPath.
+func (n *Node) FilterShortestPath(target primitive.TypedID, edgeType primitive.Type, filter EdgeFilter, weight func(*Edge) float64) ([]*Edge, float64, error) {
+	if _, ok := n.g().Node(target); !ok {
+		return nil, 0, ErrNodeNotFound
+	}
+	if weight == nil {
+		weight = func(*Edge) float64 { return 1 }
+	}
+	targetKey := key(target)
+	if key(n) == targetKey {
+		return nil, 0, nil
+	}
+
+	dist := map[string]float64{key(n): 0}
+	pq := &priorityQueue{{node: n, dist: 0}}
+	heap.Init(pq)
+	visited := map[string]bool{}
+
+	for pq.Len() > 0 {
+		cur := heap.Pop(pq).(*pqItem)
+		if visited[key(cur.node)] {
+			continue
+		}
+		visited[key(cur.node)] = true
+		if key(cur.node) == targetKey {
+			return cur.path, cur.dist, nil
+		}
+		cur.node.EdgesFrom(edgeType, func(e *Edge) bool {
+			if filter != nil && !filter(e) {
+				return true
+			}
+			peer := e.To()
+			if visited[key(peer)] {
+				return true
+			}
+			next := cur.dist + weight(e)
+			if d, ok := dist[key(peer)]; ok && d <= next {
+				return true
+			}
+			dist[key(peer)] = next
+			heap.Push(pq, &pqItem{node: peer, dist: next, path: append(append([]*Edge{}, cur.path...), e)})
+			return true
+		})
+	}
+	return nil, 0, ErrPathNotFound
+}
@@ -0,0 +1,144 @@
+package dagger
+
+import (
+	"testing"
+
+	"github.com/autom8ter/dagger/primitive"
+)
+
+// newTraverseFixture builds:
+//
+//	a -[road,cost=1]-> b -[road,cost=5]-> d
+//	a -[road,cost=1]-> c -[road,cost=1]-> d
+//
+// so the shortest a->d path goes through c (cost 2) rather than b (cost 6).
+func newTraverseFixture(t *testing.T) (a, b, c, d *Node) {
+	t.Helper()
+	mk := func(id string) *Node {
+		n, err := NewNode(map[string]interface{}{primitive.TYPE_KEY: "traverse_node", primitive.ID_KEY: id})
+		if err != nil {
+			t.Fatalf("NewNode(%s): %v", id, err)
+		}
+		return n
+	}
+	a, b, c, d = mk("a"), mk("b"), mk("c"), mk("d")
+
+	connect := func(from, to *Node, cost int) {
+		edge, err := from.Connect(to, "road", false, nil)
+		if err != nil {
+			t.Fatalf("Connect(%s -> %s): %v", from.ID(), to.ID(), err)
+		}
+		if err := edge.Patch(map[string]interface{}{"cost": cost}); err != nil {
+			t.Fatalf("Patch(%s -> %s): %v", from.ID(), to.ID(), err)
+		}
+	}
+	connect(a, b, 1)
+	connect(b, d, 5)
+	connect(a, c, 1)
+	connect(c, d, 1)
+	return a, b, c, d
+}
+
+func TestShortestPath(t *testing.T) {
+	a, _, c, d := newTraverseFixture(t)
+	weight := func(e *Edge) float64 { return float64(e.GetInt("cost")) }
+
+	path, dist, err := a.ShortestPath(d, "road", weight)
+	if err != nil {
+		t.Fatalf("ShortestPath: %v", err)
+	}
+	if dist != 2 {
+		t.Fatalf("expected shortest distance 2, got %v", dist)
+	}
+	if len(path) != 2 || path[0].To().ID() != c.ID() || path[1].To().ID() != d.ID() {
+		t.Fatalf("expected path a->c->d, got %d hops", len(path))
+	}
+}
+
+func TestShortestPathNodeNotFound(t *testing.T) {
+	a, _, _, _ := newTraverseFixture(t)
+	missing := typedID{typ: "traverse_node", id: "does-not-exist"}
+	if _, _, err := a.ShortestPath(missing, "road", nil); err != ErrNodeNotFound {
+		t.Fatalf("expected ErrNodeNotFound, got %v", err)
+	}
+}
+
+func TestShortestPathUnreachable(t *testing.T) {
+	a, _, _, _ := newTraverseFixture(t)
+	isolated, err := NewNode(map[string]interface{}{primitive.TYPE_KEY: "traverse_node", primitive.ID_KEY: "isolated"})
+	if err != nil {
+		t.Fatalf("NewNode: %v", err)
+	}
+	if _, _, err := a.ShortestPath(isolated, "road", nil); err != ErrPathNotFound {
+		t.Fatalf("expected ErrPathNotFound, got %v", err)
+	}
+}
+
+func TestBFSVisitsEveryReachableNode(t *testing.T) {
+	a, b, c, d := newTraverseFixture(t)
+	seen := map[string]bool{}
+	a.BFS("road", 0, func(path []*Edge, node *Node) bool {
+		seen[node.ID()] = true
+		return true
+	})
+	for _, n := range []*Node{b, c, d} {
+		if !seen[n.ID()] {
+			t.Fatalf("BFS did not visit %s", n.ID())
+		}
+	}
+}
+
+// TestShortestPathOnNonGlobalGraph guards against ShortestPath's target-existence guard querying globalGraph
+// instead of n's own graph, which made it return ErrNodeNotFound on any Graph obtained from Open/NewGraph even when
+// the target and a valid path existed.
+func TestShortestPathOnNonGlobalGraph(t *testing.T) {
+	g := NewGraph(newMemStore())
+	a, err := g.NewNode(map[string]interface{}{primitive.TYPE_KEY: "traverse_node", primitive.ID_KEY: "a"})
+	if err != nil {
+		t.Fatalf("NewNode(a): %v", err)
+	}
+	b, err := g.NewNode(map[string]interface{}{primitive.TYPE_KEY: "traverse_node", primitive.ID_KEY: "b"})
+	if err != nil {
+		t.Fatalf("NewNode(b): %v", err)
+	}
+	if _, err := a.Connect(b, "road", false, nil); err != nil {
+		t.Fatalf("Connect(a -> b): %v", err)
+	}
+
+	path, dist, err := a.ShortestPath(b, "road", nil)
+	if err != nil {
+		t.Fatalf("ShortestPath: %v", err)
+	}
+	if dist != 1 || len(path) != 1 {
+		t.Fatalf("expected a single-hop path of distance 1, got %d hops at distance %v", len(path), dist)
+	}
+}
+
+func TestFilterShortestPathExcludesFilteredEdges(t *testing.T) {
+	a, _, c, d := newTraverseFixture(t)
+	weight := func(e *Edge) float64 { return float64(e.GetInt("cost")) }
+	avoidC := func(e *Edge) bool { return e.To().ID() != c.ID() }
+
+	path, dist, err := a.FilterShortestPath(d, "road", avoidC, weight)
+	if err != nil {
+		t.Fatalf("FilterShortestPath: %v", err)
+	}
+	if dist != 6 {
+		t.Fatalf("expected the filtered path to detour through b at cost 6, got %v", dist)
+	}
+	if len(path) != 2 || path[0].To().ID() == c.ID() {
+		t.Fatalf("expected the path to avoid c, got %d hops through %v", len(path), path)
+	}
+}
+
+func TestDFSStopsEarly(t *testing.T) {
+	a, _, _, _ := newTraverseFixture(t)
+	visits := 0
+	a.DFS("road", 0, func(path []*Edge, node *Node) bool {
+		visits++
+		return false
+	})
+	if visits != 1 {
+		t.Fatalf("expected DFS to stop after the first visit, got %d", visits)
+	}
+}